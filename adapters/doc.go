@@ -0,0 +1,20 @@
+// Copyright 2021 Eric Sammer
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package adapters provides prebuilt tasks.Task constructors for common long-running components - HTTP servers, raw
+// net.Listener accept loops, and gRPC servers - so callers don't need to hand-roll the same "run until cancelled,
+// then shut down gracefully" boilerplate for every application. Unlike the root go_tasks package, adapters is not
+// zero-dependency; it imports the standard library packages (and, for GRPCServerTask, google.golang.org/grpc) for
+// the components it wraps.
+package adapters
@@ -0,0 +1,60 @@
+// Copyright 2021 Eric Sammer
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapters
+
+import (
+	"net"
+	"time"
+
+	tasks "github.com/esammer/go-tasks"
+	"google.golang.org/grpc"
+)
+
+// GRPCServerTask returns a tasks.Task that runs server.Serve(l) until cancelled, then calls GracefulStop, waiting up
+// to shutdownGrace for in-flight RPCs to finish. If GracefulStop hasn't returned within shutdownGrace, it falls back
+// to server.Stop(), which closes all connections immediately. A shutdownGrace of 0 uses DefaultShutdownGrace.
+func GRPCServerTask(server *grpc.Server, l net.Listener, shutdownGrace time.Duration) tasks.Task {
+	if shutdownGrace <= 0 {
+		shutdownGrace = DefaultShutdownGrace
+	}
+
+	return func(cancelC <-chan struct{}) error {
+		serveErrC := make(chan error, 1)
+		go func() {
+			serveErrC <- server.Serve(l)
+		}()
+
+		select {
+		case err := <-serveErrC:
+			return err
+		case <-cancelC:
+			stoppedC := make(chan struct{})
+			go func() {
+				server.GracefulStop()
+				close(stoppedC)
+			}()
+
+			select {
+			case <-stoppedC:
+			case <-time.After(shutdownGrace):
+				server.Stop()
+				<-stoppedC
+			}
+
+			<-serveErrC
+			return nil
+		}
+	}
+}
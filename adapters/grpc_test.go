@@ -0,0 +1,102 @@
+package adapters
+
+import (
+	"context"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"net"
+	"testing"
+	"time"
+)
+
+// blockingService backs a single streaming RPC whose handler blocks until its stream's context is done, giving tests
+// an RPC that's reliably still in-flight when GracefulStop is called.
+type blockingService struct {
+	startedC chan struct{}
+}
+
+var blockingServiceDesc = grpc.ServiceDesc{
+	ServiceName: "blocking.Blocking",
+	HandlerType: (*interface{})(nil),
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Stream",
+			Handler:       blockingStreamHandler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+}
+
+func blockingStreamHandler(srv interface{}, stream grpc.ServerStream) error {
+	s := srv.(blockingService)
+	close(s.startedC)
+	<-stream.Context().Done()
+	return stream.Context().Err()
+}
+
+func TestGRPCServerTask_GracefulStopsOnCancel(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := grpc.NewServer()
+	task := GRPCServerTask(server, ln, time.Second)
+
+	cancelC := make(chan struct{})
+	doneC := make(chan error, 1)
+	go func() {
+		doneC <- task(cancelC)
+	}()
+
+	// Give the server a moment to start serving before asking it to stop; there's no in-flight RPC here, so
+	// GracefulStop should return almost immediately.
+	time.Sleep(time.Millisecond * 50)
+	close(cancelC)
+
+	select {
+	case err := <-doneC:
+		require.NoError(t, err)
+	case <-time.After(time.Second * 5):
+		t.Fatal("GRPCServerTask did not shut down in time")
+	}
+}
+
+func TestGRPCServerTask_FallsBackToStopWhenGraceExpires(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := grpc.NewServer()
+	startedC := make(chan struct{})
+	server.RegisterService(&blockingServiceDesc, blockingService{startedC: startedC})
+
+	task := GRPCServerTask(server, ln, time.Millisecond*50)
+
+	cancelC := make(chan struct{})
+	doneC := make(chan error, 1)
+	go func() {
+		doneC <- task(cancelC)
+	}()
+
+	dialCtx, dialCancel := context.WithTimeout(context.Background(), time.Second)
+	defer dialCancel()
+	conn, err := grpc.DialContext(dialCtx, ln.Addr().String(),
+		grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithBlock())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	_, err = conn.NewStream(context.Background(), &blockingServiceDesc.Streams[0], "/blocking.Blocking/Stream")
+	require.NoError(t, err)
+	<-startedC // The handler is running, so GracefulStop has an in-flight RPC to wait on.
+
+	close(cancelC)
+
+	select {
+	case err := <-doneC:
+		// shutdownGrace is far shorter than the blocked RPC, so the task must fall back to server.Stop(), which
+		// forcibly tears down the in-flight stream instead of waiting for it.
+		require.NoError(t, err)
+	case <-time.After(time.Second * 5):
+		t.Fatal("GRPCServerTask did not fall back to Stop in time")
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright 2021 Eric Sammer
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapters
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	tasks "github.com/esammer/go-tasks"
+)
+
+// DefaultShutdownGrace is the grace period the adapters in this package wait for in-flight work to finish during a
+// graceful shutdown, used when the caller doesn't supply one.
+const DefaultShutdownGrace = 30 * time.Second
+
+// HTTPServerTask returns a tasks.Task that runs server's ListenAndServe until cancelled, then calls Shutdown,
+// waiting up to shutdownGrace for in-flight requests to finish before returning. A shutdownGrace of 0 uses
+// DefaultShutdownGrace.
+//
+// Startup errors - most importantly "address already in use" - are surfaced as soon as ListenAndServe returns rather
+// than blocking until cancellation, so callers in a FixedTaskGroup or DynamicTaskGroup notice them promptly. The
+// error ListenAndServe always returns on a clean shutdown, http.ErrServerClosed, is not treated as a failure.
+func HTTPServerTask(server *http.Server, shutdownGrace time.Duration) tasks.Task {
+	if shutdownGrace <= 0 {
+		shutdownGrace = DefaultShutdownGrace
+	}
+
+	return func(cancelC <-chan struct{}) error {
+		serveErrC := make(chan error, 1)
+		go func() {
+			serveErrC <- server.ListenAndServe()
+		}()
+
+		select {
+		case err := <-serveErrC:
+			if err != nil && !errors.Is(err, http.ErrServerClosed) {
+				return err
+			}
+			return nil
+		case <-cancelC:
+			ctx, cancel := context.WithTimeout(context.Background(), shutdownGrace)
+			defer cancel()
+
+			if err := server.Shutdown(ctx); err != nil {
+				return err
+			}
+
+			<-serveErrC
+			return nil
+		}
+	}
+}
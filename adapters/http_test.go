@@ -0,0 +1,58 @@
+package adapters
+
+import (
+	"errors"
+	"github.com/stretchr/testify/require"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestHTTPServerTask_ShutsDownOnCancel(t *testing.T) {
+	server := &http.Server{Addr: "127.0.0.1:0"}
+	task := HTTPServerTask(server, time.Second)
+
+	cancelC := make(chan struct{})
+	doneC := make(chan error, 1)
+	go func() {
+		doneC <- task(cancelC)
+	}()
+
+	close(cancelC)
+
+	select {
+	case err := <-doneC:
+		require.NoError(t, err)
+	case <-time.After(time.Second * 5):
+		t.Fatal("HTTPServerTask did not shut down in time")
+	}
+}
+
+func TestHTTPServerTask_SurfacesStartupError(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := ln.Addr().String()
+	require.NoError(t, ln.Close())
+
+	blocker := &http.Server{Addr: addr}
+	blockerCancelC := make(chan struct{})
+	blockerDoneC := make(chan error, 1)
+	go func() {
+		blockerDoneC <- HTTPServerTask(blocker, time.Second)(blockerCancelC)
+	}()
+	defer func() {
+		close(blockerCancelC)
+		<-blockerDoneC
+	}()
+
+	// Give the blocker a moment to bind before reusing its address.
+	time.Sleep(time.Millisecond * 100)
+
+	conflict := &http.Server{Addr: addr}
+	task := HTTPServerTask(conflict, time.Second)
+
+	err = task(make(chan struct{}))
+	require.Error(t, err)
+	require.False(t, errors.Is(err, http.ErrServerClosed))
+}
@@ -0,0 +1,49 @@
+// Copyright 2021 Eric Sammer
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapters
+
+import (
+	"net"
+
+	tasks "github.com/esammer/go-tasks"
+)
+
+// ListenerTask returns a tasks.Task that accepts connections from l, dispatching each to handle in its own
+// goroutine, until cancelled. Cancellation is implemented by closing l, which unblocks the in-flight Accept call;
+// handle is responsible for respecting cancellation of any work it starts on the connections it's given (e.g. by
+// closing them itself, or by closing over cancelC).
+func ListenerTask(l net.Listener, handle func(net.Conn)) tasks.Task {
+	return func(cancelC <-chan struct{}) error {
+		go func() {
+			<-cancelC
+			_ = l.Close()
+		}()
+
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				select {
+				case <-cancelC:
+					// l was closed because of cancellation; this isn't a failure.
+					return nil
+				default:
+					return err
+				}
+			}
+
+			go handle(conn)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package adapters
+
+import (
+	"github.com/stretchr/testify/require"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestListenerTask_HandlesConnectionsUntilCancelled(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	handled := int64(0)
+	task := ListenerTask(ln, func(conn net.Conn) {
+		atomic.AddInt64(&handled, 1)
+		_ = conn.Close()
+	})
+
+	cancelC := make(chan struct{})
+	doneC := make(chan error, 1)
+	go func() {
+		doneC <- task(cancelC)
+	}()
+
+	conn, err := net.Dial("tcp", ln.Addr().String())
+	require.NoError(t, err)
+	_ = conn.Close()
+
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt64(&handled) == 1
+	}, time.Second, time.Millisecond*10)
+
+	close(cancelC)
+
+	select {
+	case err := <-doneC:
+		require.NoError(t, err)
+	case <-time.After(time.Second * 5):
+		t.Fatal("ListenerTask did not return after cancellation")
+	}
+}
@@ -20,4 +20,12 @@
 //
 // FixedTaskGroup implements a worker group of a known-size that shares a lifecycle. It's extremely useful for cases
 // where you have a group of tasks that operate together.
+//
+// DynamicTaskGroup implements the same shared-lifecycle idea for a group whose size isn't known up front, rooting
+// cancellation in a context.Context so deadlines, cancellation causes, and request-scoped values propagate the way
+// they do with errgroup. FixedTaskGroup is implemented on top of it.
+//
+// SharedGroup deduplicates in-flight work by key, singleflight-style, for callers that want to coalesce concurrent
+// requests for the same cancelable operation. Supervisor adds Erlang/OTP-style restart-with-backoff semantics to an
+// existing Task.
 package go_tasks
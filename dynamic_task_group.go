@@ -0,0 +1,189 @@
+// Copyright 2021 Eric Sammer
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package go_tasks
+
+import (
+	"context"
+	"runtime"
+	"sync"
+)
+
+// Option configures a DynamicTaskGroup at construction time.
+type Option func(*DynamicTaskGroup)
+
+// FailFast causes the group to cancel its context - and therefore every sibling task - as soon as any task returns a
+// non-nil error, rather than waiting for the caller to notice and call Cancel() itself.
+func FailFast() Option {
+	return func(g *DynamicTaskGroup) {
+		g.state.failFast = true
+	}
+}
+
+// Limit bounds the number of tasks that may run concurrently to n using a semaphore. Additional tasks started via
+// Go or GoCtx block until a slot frees up or the group's context is done. A group created without Limit allows an
+// unbounded number of tasks to run concurrently.
+func Limit(n int) Option {
+	return func(g *DynamicTaskGroup) {
+		g.state.semC = make(chan struct{}, n)
+	}
+}
+
+// dynamicTaskGroupState holds everything a running task needs. It's kept separate from DynamicTaskGroup itself, and
+// referenced only via its own pointer by task goroutines, so that a DynamicTaskGroup a caller has abandoned can
+// still be garbage collected - and its finalizer run - even while its tasks are still blocked on state.ctx.Done().
+// See DynamicTaskGroup's doc comment for why that separation matters.
+type dynamicTaskGroupState struct {
+	ctx    context.Context
+	cancel context.CancelCauseFunc
+
+	wg   sync.WaitGroup
+	semC chan struct{}
+
+	failFast bool
+
+	mu  sync.Mutex
+	err error
+
+	doneC chan struct{}
+}
+
+// DynamicTaskGroup is a group of tasks, started via Go or GoCtx, that share a lifecycle rooted in a parent
+// context.Context.
+//
+// Unlike FixedTaskGroup, the set of tasks need not be known at construction time; callers may call Go any number of
+// times for as long as the group hasn't been Cancel()ed. This mirrors the ergonomics of golang.org/x/sync/errgroup
+// while keeping this package's cancellation-channel based Task convention available via Go. Use GoCtx directly when
+// a task needs to propagate deadlines, a cancellation cause, or request-scoped values from the parent
+// context.Context.
+//
+// Cancelling the parent context.Context, calling Cancel, or (with the FailFast option) any task returning a non-nil
+// error all have the same effect: the group's context is cancelled and every task sees that via ctx.Done().
+//
+// A DynamicTaskGroup that is dropped without Cancel ever being called - for example because the caller forgot, or an
+// error path returned early - would otherwise leak any tasks still blocked on state.ctx.Done() forever. To guard
+// against that, construction registers a runtime.SetFinalizer that cancels the group once it becomes unreachable.
+// Cancel and Wait both clear that finalizer, since at that point the group is cancelled (or complete) through the
+// normal path and the safety net is no longer needed. The finalizer can only run because task goroutines reference
+// the group's internal state rather than the *DynamicTaskGroup itself; anything that keeps the *DynamicTaskGroup
+// reachable (such as a goroutine blocked in its Wait) keeps the finalizer from running.
+type DynamicTaskGroup struct {
+	state *dynamicTaskGroupState
+}
+
+// NewDynamicTaskGroup creates a DynamicTaskGroup whose tasks are bound to ctx.
+func NewDynamicTaskGroup(ctx context.Context, opts ...Option) *DynamicTaskGroup {
+	childCtx, cancel := context.WithCancelCause(ctx)
+
+	g := &DynamicTaskGroup{
+		state: &dynamicTaskGroupState{
+			ctx:    childCtx,
+			cancel: cancel,
+			doneC:  make(chan struct{}),
+		},
+	}
+
+	for _, opt := range opts {
+		opt(g)
+	}
+
+	runtime.SetFinalizer(g, func(g *DynamicTaskGroup) {
+		g.state.cancel(ErrTaskCancelled)
+	})
+
+	return g
+}
+
+// Go starts task in a new goroutine bound to the group's lifecycle, adapting the group's context.Context to task's
+// cancelC.
+//
+// It is not safe to call Go after Wait has returned.
+func (g *DynamicTaskGroup) Go(task Task) {
+	g.GoCtx(func(ctx context.Context) error {
+		return task(ctx.Done())
+	})
+}
+
+// GoCtx starts task in a new goroutine bound to the group's lifecycle, passing it the group's context.Context
+// directly so deadlines, cancellation causes, and values flow through to it.
+//
+// If the group was created with the Limit option, GoCtx blocks until a slot is available or the group's context is
+// done. It is not safe to call GoCtx after Wait has returned.
+func (g *DynamicTaskGroup) GoCtx(task TaskCtx) {
+	// NB: The spawned goroutine below closes over state, not g, so a group the caller has dropped can still be
+	// collected - and its finalizer run - while this task is running. See DynamicTaskGroup's doc comment.
+	state := g.state
+
+	if state.semC != nil {
+		select {
+		case state.semC <- struct{}{}:
+		case <-state.ctx.Done():
+			return
+		}
+	}
+
+	state.wg.Add(1)
+	go func() {
+		defer state.wg.Done()
+		if state.semC != nil {
+			defer func() { <-state.semC }()
+		}
+
+		if err := task(state.ctx); err != nil {
+			state.recordErr(err)
+		}
+	}()
+}
+
+// recordErr keeps the first error seen by the group and, in FailFast mode, cancels the group's context with it.
+func (s *dynamicTaskGroupState) recordErr(err error) {
+	s.mu.Lock()
+	if s.err == nil {
+		s.err = err
+	}
+	s.mu.Unlock()
+
+	if s.failFast {
+		s.cancel(err)
+	}
+}
+
+// Cancel cancels the group's context with ErrTaskCancelled, signalling every running task to stop.
+//
+// It is safe to call this method multiple times and from multiple goroutines.
+func (g *DynamicTaskGroup) Cancel() {
+	runtime.SetFinalizer(g, nil)
+	g.state.cancel(ErrTaskCancelled)
+}
+
+// Wait blocks until every task started with Go or GoCtx has returned, then returns the first non-nil error produced,
+// if any.
+//
+// It is not safe to call Go or GoCtx after Wait has returned.
+func (g *DynamicTaskGroup) Wait() error {
+	g.state.wg.Wait()
+	close(g.state.doneC)
+	runtime.SetFinalizer(g, nil)
+
+	g.state.mu.Lock()
+	defer g.state.mu.Unlock()
+	return g.state.err
+}
+
+// DoneC returns a channel that is closed once Wait has observed the completion of every task started so far.
+//
+// This method always returns the same channel.
+func (g *DynamicTaskGroup) DoneC() <-chan struct{} {
+	return g.state.doneC
+}
@@ -0,0 +1,153 @@
+package go_tasks
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/require"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDynamicTaskGroup_TasksCalled(t *testing.T) {
+	tasksCalled := int64(0)
+	task := func(cancelC <-chan struct{}) error {
+		atomic.AddInt64(&tasksCalled, 1)
+		return nil
+	}
+
+	g := NewDynamicTaskGroup(context.Background())
+	g.Go(task)
+	g.Go(task)
+
+	require.NoError(t, g.Wait())
+	<-g.DoneC()
+	require.Equal(t, int64(2), atomic.LoadInt64(&tasksCalled))
+}
+
+func TestDynamicTaskGroup_GoAfterStart(t *testing.T) {
+	tasksCalled := int64(0)
+	task := func(ctx context.Context) error {
+		atomic.AddInt64(&tasksCalled, 1)
+		return nil
+	}
+
+	g := NewDynamicTaskGroup(context.Background())
+	g.GoCtx(task)
+	<-time.After(time.Millisecond * 10)
+	g.GoCtx(task)
+
+	require.NoError(t, g.Wait())
+	require.Equal(t, int64(2), atomic.LoadInt64(&tasksCalled))
+}
+
+func TestDynamicTaskGroup_FirstErrorReturned(t *testing.T) {
+	g := NewDynamicTaskGroup(context.Background())
+
+	g.Go(func(cancelC <-chan struct{}) error {
+		return errors.New("task A")
+	})
+	g.Go(func(cancelC <-chan struct{}) error {
+		return errors.New("task B")
+	})
+
+	err := g.Wait()
+	require.Error(t, err)
+	require.Contains(t, []string{"task A", "task B"}, err.Error())
+}
+
+func TestDynamicTaskGroup_FailFastCancelsSiblings(t *testing.T) {
+	cancelled := int64(0)
+
+	g := NewDynamicTaskGroup(context.Background(), FailFast())
+
+	g.Go(func(cancelC <-chan struct{}) error {
+		<-cancelC
+		atomic.AddInt64(&cancelled, 1)
+		return nil
+	})
+	g.Go(func(cancelC <-chan struct{}) error {
+		return errors.New("boom")
+	})
+
+	err := g.Wait()
+	require.EqualError(t, err, "boom")
+	require.Equal(t, int64(1), atomic.LoadInt64(&cancelled))
+}
+
+func TestDynamicTaskGroup_Limit(t *testing.T) {
+	const limit = 2
+
+	inFlight := int64(0)
+	maxInFlight := int64(0)
+
+	g := NewDynamicTaskGroup(context.Background(), Limit(limit))
+
+	for i := 0; i < 10; i++ {
+		g.Go(func(cancelC <-chan struct{}) error {
+			n := atomic.AddInt64(&inFlight, 1)
+			for {
+				max := atomic.LoadInt64(&maxInFlight)
+				if n <= max || atomic.CompareAndSwapInt64(&maxInFlight, max, n) {
+					break
+				}
+			}
+			<-time.After(time.Millisecond * 10)
+			atomic.AddInt64(&inFlight, -1)
+			return nil
+		})
+	}
+
+	require.NoError(t, g.Wait())
+	require.LessOrEqual(t, atomic.LoadInt64(&maxInFlight), int64(limit))
+}
+
+func TestDynamicTaskGroup_LeakProtection(t *testing.T) {
+	cancelObservedC := make(chan struct{})
+
+	func() {
+		g := NewDynamicTaskGroup(context.Background())
+		g.Go(func(cancelC <-chan struct{}) error {
+			<-cancelC
+			close(cancelObservedC)
+			return nil
+		})
+		_ = g // Dropped at the end of this scope without ever calling Cancel().
+	}()
+
+	require.Eventually(t, func() bool {
+		runtime.GC()
+
+		select {
+		case <-cancelObservedC:
+			return true
+		default:
+			return false
+		}
+	}, time.Second*10, time.Millisecond*50)
+}
+
+func TestDynamicTaskGroup_Cancel(t *testing.T) {
+	cancelled := int64(0)
+	g := NewDynamicTaskGroup(context.Background())
+
+	g.Go(func(cancelC <-chan struct{}) error {
+		select {
+		case <-time.After(time.Minute):
+			return errors.New("timed out")
+		case <-cancelC:
+			atomic.AddInt64(&cancelled, 1)
+		}
+
+		return nil
+	})
+
+	g.Cancel()
+	require.NotPanics(t, func() {
+		g.Cancel() // This should be fine
+	})
+
+	require.NoError(t, g.Wait())
+	require.Equal(t, int64(1), atomic.LoadInt64(&cancelled))
+}
@@ -14,7 +14,10 @@
 
 package go_tasks
 
-import "sync"
+import (
+	"context"
+	"runtime"
+)
 
 // FixedTaskGroup is a fixed number of tasks that share a lifecycle.
 //
@@ -22,56 +25,63 @@ import "sync"
 // considered done when all tasks have completed. Additionally, the group may be cancelled as a unit. The number of
 // tasks is fixed at creation time making termination simple (i.e. "done" is when all tasks reach completion).
 //
+// FixedTaskGroup is implemented on top of DynamicTaskGroup, so its cancellation and error propagation semantics are
+// compatible; unlike DynamicTaskGroup, it reports every task's error (not just the first) via ErrC, which matches
+// its fixed, known-up-front set of tasks.
+//
+// Like DynamicTaskGroup, a FixedTaskGroup that's dropped without Cancel ever being called, or Wait ever returning, is
+// still protected by a runtime.SetFinalizer registered at construction; see DynamicTaskGroup's doc comment for the
+// reachability caveat this relies on. Cancel and Wait both clear that finalizer, since by the time either returns
+// the group has reached the end of its lifecycle through the normal path and no longer needs the safety net.
+//
 // If ErrC is closed, you can be sure that there are no resource leaks.
 type FixedTaskGroup struct {
-	tasks []Task
-	wg    *sync.WaitGroup
-	errC  chan error
+	dtg *DynamicTaskGroup
 
-	cancelC    chan struct{}
-	cancelOnce *sync.Once
+	errC  chan error
+	doneC chan struct{}
 }
 
 // NewFixedTaskGroup creates and starts a group of tasks that share a lifecycle.
 func NewFixedTaskGroup(tasks ...Task) *FixedTaskGroup {
-	tg := &FixedTaskGroup{
-		tasks: tasks,
-		wg:    &sync.WaitGroup{},
-		// NB: We allocate just enough space for each task to produce an error. If more tasks than capacity exist _and_
-		// the caller doesn't consume the error channel completely it's possible to leak go routines, and we wouldn't
-		// make good on our promise to not leak resources when ErrC() is closed.
-		errC: make(chan error, len(tasks)),
-
-		cancelC:    make(chan struct{}),
-		cancelOnce: &sync.Once{},
-	}
+	dtg := NewDynamicTaskGroup(context.Background())
+	// NB: We allocate just enough space for each task to produce an error. If more tasks than capacity exist _and_
+	// the caller doesn't consume the error channel completely it's possible to leak go routines, and we wouldn't
+	// make good on our promise to not leak resources when ErrC() is closed.
+	errC := make(chan error, len(tasks))
+	doneC := make(chan struct{})
 
 	for _, task := range tasks {
-		tg.startTask(task)
+		startTask(dtg, errC, task)
 	}
 
-	// Close errC when all tasks are complete.
+	// Close errC and doneC once all tasks are complete. This closure intentionally captures dtg, errC and doneC
+	// directly rather than the *FixedTaskGroup returned below, so the group can still be collected - and its
+	// finalizer run - while it's abandoned with tasks still running.
 	go func() {
-		tg.wg.Wait()
-		defer close(tg.errC)
+		dtg.Wait()
+		close(errC)
+		close(doneC)
 	}()
 
-	return tg
-}
+	g := &FixedTaskGroup{dtg: dtg, errC: errC, doneC: doneC}
 
-// Adds and starts a single task to the group.
-//
-// Note that calling startTask() after someone has starting listening
-func (g *FixedTaskGroup) startTask(task Task) {
-	g.tasks = append(g.tasks, task)
-	g.wg.Add(1)
-	go func() {
-		defer g.wg.Done()
+	runtime.SetFinalizer(g, func(g *FixedTaskGroup) {
+		g.dtg.Cancel()
+	})
+
+	return g
+}
 
-		if err := task(g.cancelC); err != nil {
-			g.errC <- err
+// Starts a single task against dtg, forwarding any error it produces to errC.
+func startTask(dtg *DynamicTaskGroup, errC chan<- error, task Task) {
+	dtg.Go(func(cancelC <-chan struct{}) error {
+		err := task(cancelC)
+		if err != nil {
+			errC <- err
 		}
-	}()
+		return err
+	})
 }
 
 // Cancel signals all tasks to stop.
@@ -83,9 +93,8 @@ func (g *FixedTaskGroup) startTask(task Task) {
 // It is safe to call this method multiple times and from multiple threads, although it has no effect after the first
 // call.
 func (g *FixedTaskGroup) Cancel() {
-	g.cancelOnce.Do(func() {
-		close(g.cancelC)
-	})
+	runtime.SetFinalizer(g, nil)
+	g.dtg.Cancel()
 }
 
 // ErrC returns a channel that will contain any task errors.
@@ -106,3 +115,22 @@ func (g *FixedTaskGroup) Cancel() {
 func (g *FixedTaskGroup) ErrC() <-chan error {
 	return g.errC
 }
+
+// DoneC returns a channel that is closed once every task in the group has completed, after all errors have been
+// delivered to ErrC.
+//
+// This method always returns the same channel.
+func (g *FixedTaskGroup) DoneC() <-chan struct{} {
+	return g.doneC
+}
+
+// Wait blocks until every task in the group has completed, then returns.
+//
+// Calling Wait clears the finalizer registered at construction: since it only returns once the group has completed
+// through the normal path, the GC safety net is no longer needed. Callers who instead drain ErrC directly (or just
+// read from DoneC) get the same termination behavior but leave the finalizer in place until GC runs it, which is
+// harmless - Cancel on an already-complete group is a no-op - but costs an extra GC pass.
+func (g *FixedTaskGroup) Wait() {
+	<-g.doneC
+	runtime.SetFinalizer(g, nil)
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"github.com/stretchr/testify/require"
+	"runtime"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -74,6 +75,47 @@ func TestFixedTaskGroup_Cancel(t *testing.T) {
 	require.Equal(t, int64(1), atomic.LoadInt64(&cancelled))
 }
 
+func TestFixedTaskGroup_LeakProtection(t *testing.T) {
+	cancelObservedC := make(chan struct{})
+
+	func() {
+		tg := NewFixedTaskGroup(func(cancelC <-chan struct{}) error {
+			<-cancelC
+			close(cancelObservedC)
+			return nil
+		})
+		_ = tg // Dropped at the end of this scope without ever calling Cancel().
+	}()
+
+	require.Eventually(t, func() bool {
+		runtime.GC()
+
+		select {
+		case <-cancelObservedC:
+			return true
+		default:
+			return false
+		}
+	}, time.Second*10, time.Millisecond*50)
+}
+
+func TestFixedTaskGroup_WaitReturnsAfterCompletion(t *testing.T) {
+	tasksCalled := int64(0)
+	task := func(cancelC <-chan struct{}) error {
+		atomic.AddInt64(&tasksCalled, 1)
+		return nil
+	}
+
+	tg := NewFixedTaskGroup(task, task)
+
+	tg.Wait()
+	require.Equal(t, int64(2), atomic.LoadInt64(&tasksCalled))
+
+	require.NotPanics(t, func() {
+		tg.Wait() // Should be fine to call again.
+	})
+}
+
 func ExampleFixedTaskGroup() {
 	taskFact := func(taskId int, iters int) Task {
 		return func(cancelC <-chan struct{}) error {
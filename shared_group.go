@@ -0,0 +1,121 @@
+// Copyright 2021 Eric Sammer
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package go_tasks
+
+import (
+	"context"
+	"sync"
+)
+
+// Result carries the outcome of a SharedGroup execution to a single Do caller.
+type Result[V any] struct {
+	Val V
+	Err error
+}
+
+// SharedGroup deduplicates in-flight work by key, singleflight-style: concurrent callers requesting the same key
+// share a single execution of task and each independently receive its result.
+//
+// Unlike golang.org/x/sync/singleflight, every caller supplies its own context.Context to Do, and the shared
+// execution's context is only cancelled once every caller currently waiting on that key has had its own context
+// fire - tracked with a reference count - so one caller giving up early doesn't abort work that other callers are
+// still waiting on. This makes it safe to coalesce cancelable operations, such as upstream fetches or cache fills,
+// behind a single in-flight execution.
+type SharedGroup[K comparable, V any] struct {
+	mu    sync.Mutex
+	calls map[K]*sharedCall[V]
+}
+
+// NewSharedGroup creates an empty SharedGroup.
+func NewSharedGroup[K comparable, V any]() *SharedGroup[K, V] {
+	return &SharedGroup[K, V]{calls: make(map[K]*sharedCall[V])}
+}
+
+// sharedCall tracks a single in-flight execution shared by the callers currently waiting on it. waiters and subs are
+// mutated only while the owning SharedGroup's mu is held, so that a waiter count reaching zero and a new caller
+// joining the same key can never interleave.
+type sharedCall[V any] struct {
+	waiters int64
+	cancel  context.CancelCauseFunc
+
+	doneC chan struct{}
+	subs  []chan<- Result[V]
+}
+
+// Do executes task for key, or joins its in-flight execution if one is already running for key, and returns a
+// channel that receives exactly one Result once that execution completes.
+//
+// If ctx is done before the execution completes, Do stops counting the caller as a waiter on key: the shared
+// execution's context.Context is cancelled with ctx's error only once every caller currently waiting on key has done
+// the same. The returned channel still receives the execution's Result whenever that execution completes.
+func (g *SharedGroup[K, V]) Do(ctx context.Context, key K, task func(ctx context.Context) (V, error)) <-chan Result[V] {
+	resultC := make(chan Result[V], 1)
+
+	g.mu.Lock()
+	call, inFlight := g.calls[key]
+	if !inFlight {
+		callCtx, cancel := context.WithCancelCause(context.Background())
+		call = &sharedCall[V]{cancel: cancel, doneC: make(chan struct{})}
+		g.calls[key] = call
+
+		go g.run(key, call, callCtx, task)
+	}
+	call.waiters++
+	call.subs = append(call.subs, resultC)
+	g.mu.Unlock()
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			// The waiters-- and the zero check must happen in the same g.mu critical section as a concurrent Do's
+			// waiters++ for the same key; otherwise a new caller could join call in the gap between this goroutine
+			// deciding it's the last waiter and actually unpublishing call, and have its own live ctx cancelled on
+			// its way out.
+			g.mu.Lock()
+			call.waiters--
+			last := call.waiters == 0
+			if last && g.calls[key] == call {
+				delete(g.calls, key)
+			}
+			g.mu.Unlock()
+
+			if last {
+				call.cancel(ctx.Err())
+			}
+		case <-call.doneC:
+		}
+	}()
+
+	return resultC
+}
+
+// run executes task to completion and fans its result out to every caller that joined call.
+func (g *SharedGroup[K, V]) run(key K, call *sharedCall[V], ctx context.Context, task func(context.Context) (V, error)) {
+	val, err := task(ctx)
+	result := Result[V]{Val: val, Err: err}
+
+	// call may already have been removed from g.calls by Do, above, once its last waiter gave up.
+	g.mu.Lock()
+	if g.calls[key] == call {
+		delete(g.calls, key)
+	}
+	subs := call.subs
+	g.mu.Unlock()
+
+	for _, sub := range subs {
+		sub <- result
+	}
+	close(call.doneC)
+}
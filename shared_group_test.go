@@ -0,0 +1,162 @@
+package go_tasks
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/require"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSharedGroup_DeduplicatesConcurrentCallers(t *testing.T) {
+	g := NewSharedGroup[string, int]()
+
+	executions := int64(0)
+	task := func(ctx context.Context) (int, error) {
+		atomic.AddInt64(&executions, 1)
+		<-time.After(time.Millisecond * 100)
+		return 42, nil
+	}
+
+	const callers = 5
+	resultCs := make([]<-chan Result[int], callers)
+	for i := 0; i < callers; i++ {
+		resultCs[i] = g.Do(context.Background(), "key", task)
+	}
+
+	for _, resultC := range resultCs {
+		result := <-resultC
+		require.NoError(t, result.Err)
+		require.Equal(t, 42, result.Val)
+	}
+
+	require.Equal(t, int64(1), atomic.LoadInt64(&executions))
+}
+
+func TestSharedGroup_SeparateKeysRunIndependently(t *testing.T) {
+	g := NewSharedGroup[string, int]()
+
+	task := func(v int) func(ctx context.Context) (int, error) {
+		return func(ctx context.Context) (int, error) {
+			return v, nil
+		}
+	}
+
+	resultA := g.Do(context.Background(), "a", task(1))
+	resultB := g.Do(context.Background(), "b", task(2))
+
+	a := <-resultA
+	b := <-resultB
+
+	require.Equal(t, 1, a.Val)
+	require.Equal(t, 2, b.Val)
+}
+
+func TestSharedGroup_CancelsOnlyWhenAllWaitersGiveUp(t *testing.T) {
+	g := NewSharedGroup[string, int]()
+
+	taskCancelled := int64(0)
+	startedC := make(chan struct{})
+	task := func(ctx context.Context) (int, error) {
+		close(startedC)
+		<-ctx.Done()
+		atomic.AddInt64(&taskCancelled, 1)
+		return 0, ctx.Err()
+	}
+
+	staying, stayingCancel := context.WithCancel(context.Background())
+	defer stayingCancel()
+	leaving, leavingCancel := context.WithCancel(context.Background())
+
+	stayingResultC := g.Do(staying, "key", task)
+	leavingResultC := g.Do(leaving, "key", task)
+
+	<-startedC
+	leavingCancel()
+
+	select {
+	case <-leavingResultC:
+		t.Fatal("leaving caller should not receive a result while the shared execution is still running")
+	case <-time.After(time.Millisecond * 100):
+	}
+	require.Equal(t, int64(0), atomic.LoadInt64(&taskCancelled))
+
+	stayingCancel()
+
+	result := <-stayingResultC
+	require.True(t, errors.Is(result.Err, context.Canceled))
+	require.Equal(t, int64(1), atomic.LoadInt64(&taskCancelled))
+}
+
+func TestSharedGroup_LateJoinerAfterAllWaitersGiveUpStartsFreshExecution(t *testing.T) {
+	g := NewSharedGroup[string, int]()
+
+	executions := int64(0)
+	startedC := make(chan struct{}, 2)
+	task := func(ctx context.Context) (int, error) {
+		n := atomic.AddInt64(&executions, 1)
+		startedC <- struct{}{}
+		if n == 1 {
+			// The first execution lingers well after its context is cancelled, simulating slow unwind work, so a
+			// late joiner can arrive in the window between the last waiter giving up and task finally returning.
+			<-time.After(time.Millisecond * 200)
+			return 0, ctx.Err()
+		}
+		return 99, nil
+	}
+
+	firstCtx, firstCancel := context.WithCancel(context.Background())
+	firstResultC := g.Do(firstCtx, "key", task)
+	<-startedC
+
+	firstCancel()
+
+	time.Sleep(time.Millisecond * 20)
+
+	secondResultC := g.Do(context.Background(), "key", task)
+
+	first := <-firstResultC
+	require.True(t, errors.Is(first.Err, context.Canceled))
+
+	second := <-secondResultC
+	require.NoError(t, second.Err)
+	require.Equal(t, 99, second.Val)
+	require.Equal(t, int64(2), atomic.LoadInt64(&executions))
+}
+
+func TestSharedGroup_NoSpuriousCancelForLiveJoinerUnderRace(t *testing.T) {
+	const iterations = 300
+
+	for i := 0; i < iterations; i++ {
+		g := NewSharedGroup[string, int]()
+
+		// Buffered for two: if the monitor wins the race below, the second Do starts a fresh execution.
+		startedC := make(chan struct{}, 2)
+		task := func(ctx context.Context) (int, error) {
+			startedC <- struct{}{}
+			select {
+			case <-ctx.Done():
+				return 0, ctx.Err()
+			case <-time.After(time.Millisecond * 10):
+				return 1, nil
+			}
+		}
+
+		leaving, leavingCancel := context.WithCancel(context.Background())
+		leavingResultC := g.Do(leaving, "key", task)
+		<-startedC
+
+		// leavingCancel wakes the monitor goroutine spawned by the Do call above, which races - with no
+		// synchronization from this test - against the Do call immediately below for the same key. Neither ordering
+		// should ever cancel staying's result, since staying's own context never fires.
+		leavingCancel()
+		stayingResultC := g.Do(context.Background(), "key", task)
+
+		staying := <-stayingResultC
+		require.NoErrorf(t, staying.Err,
+			"iteration %d: a caller with a live context must never observe the shared cancellation", i)
+
+		<-leavingResultC
+	}
+}
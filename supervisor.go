@@ -0,0 +1,178 @@
+// Copyright 2021 Eric Sammer
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package go_tasks
+
+import (
+	"math/rand"
+	"time"
+)
+
+// RestartPolicy controls whether a Supervisor restarts its task after it returns.
+type RestartPolicy int
+
+const (
+	// RestartNever never restarts the task; the supervised Task returns as soon as the underlying task does.
+	RestartNever RestartPolicy = iota
+	// RestartOnError restarts the task only when it returns a non-nil error.
+	RestartOnError
+	// RestartAlways restarts the task regardless of whether it returns nil or an error.
+	RestartAlways
+)
+
+// Backoff computes the delay to wait before the nth restart of a supervised task. attempt starts at 1.
+type Backoff func(attempt int) time.Duration
+
+// ConstantBackoff returns a Backoff that always waits d between restarts.
+func ConstantBackoff(d time.Duration) Backoff {
+	return func(attempt int) time.Duration {
+		return d
+	}
+}
+
+// ExponentialBackoff returns a Backoff that waits base*2^(attempt-1), capped at max, then applies full jitter -
+// picking uniformly from [0, capped delay] - so that many supervised tasks restarting around the same time don't
+// all retry in lockstep.
+func ExponentialBackoff(base, max time.Duration) Backoff {
+	return func(attempt int) time.Duration {
+		if base <= 0 {
+			return 0
+		}
+
+		if attempt < 1 {
+			attempt = 1
+		}
+
+		d := base
+		for i := 1; i < attempt && d < max; i++ {
+			d *= 2
+			if d < 0 { // Overflowed.
+				d = max
+				break
+			}
+		}
+		if d > max {
+			d = max
+		}
+
+		return time.Duration(rand.Int63n(int64(d) + 1))
+	}
+}
+
+// RestartEvent describes a single restart performed by a Supervisor, delivered to an optional observer channel.
+type RestartEvent struct {
+	// Attempt is the restart count, starting at 1 for the first restart.
+	Attempt int
+	// Err is the error the task returned that triggered this restart, or nil if it returned no error (RestartAlways).
+	Err error
+	// Delay is the backoff the Supervisor waits before this restart; the event is sent before that wait begins.
+	Delay time.Duration
+}
+
+// SupervisorOption configures a Supervisor at construction time.
+type SupervisorOption func(*Supervisor)
+
+// WithMaxRestarts bounds the number of restarts a Supervisor will perform before giving up and returning the last
+// error the task produced. A limit of 0 (the default) means unlimited restarts.
+func WithMaxRestarts(n int) SupervisorOption {
+	return func(s *Supervisor) {
+		s.maxRestarts = n
+	}
+}
+
+// WithObserver delivers a RestartEvent to eventC for every restart the Supervisor performs. Sends are non-blocking;
+// a slow or absent reader drops events rather than stalling the supervised task.
+func WithObserver(eventC chan<- RestartEvent) SupervisorOption {
+	return func(s *Supervisor) {
+		s.observerC = eventC
+	}
+}
+
+// Supervisor wraps a Task with Erlang/OTP-style restart semantics: when the task returns, policy decides whether to
+// restart it, and backoff decides how long to wait before doing so.
+type Supervisor struct {
+	task    Task
+	policy  RestartPolicy
+	backoff Backoff
+
+	maxRestarts int
+	observerC   chan<- RestartEvent
+}
+
+// NewSupervisor creates a Supervisor that restarts task according to policy and backoff.
+func NewSupervisor(task Task, policy RestartPolicy, backoff Backoff, opts ...SupervisorOption) *Supervisor {
+	s := &Supervisor{
+		task:    task,
+		policy:  policy,
+		backoff: backoff,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// Task returns a Task that runs the supervised task, restarting it per the Supervisor's RestartPolicy and Backoff,
+// suitable for use inside a FixedTaskGroup or DynamicTaskGroup alongside ordinary tasks.
+//
+// Restarts honor cancelC: a cancellation request arriving while waiting out a restart's backoff, or in between
+// restarts, causes the returned Task to return immediately with the task's last error.
+func (s *Supervisor) Task() Task {
+	return func(cancelC <-chan struct{}) error {
+		restarts := 0
+
+		for {
+			err := s.task(cancelC)
+
+			switch s.policy {
+			case RestartNever:
+				return err
+			case RestartOnError:
+				if err == nil {
+					return nil
+				}
+			case RestartAlways:
+				// Always restart, whether or not the task produced an error.
+			}
+
+			select {
+			case <-cancelC:
+				return err
+			default:
+			}
+
+			if s.maxRestarts > 0 && restarts >= s.maxRestarts {
+				return err
+			}
+			restarts++
+
+			delay := s.backoff(restarts)
+
+			if s.observerC != nil {
+				select {
+				case s.observerC <- RestartEvent{Attempt: restarts, Err: err, Delay: delay}:
+				default:
+				}
+			}
+
+			select {
+			case <-cancelC:
+				return err
+			case <-time.After(delay):
+			}
+		}
+	}
+}
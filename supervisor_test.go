@@ -0,0 +1,121 @@
+package go_tasks
+
+import (
+	"errors"
+	"github.com/stretchr/testify/require"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSupervisor_RestartOnErrorStopsOnSuccess(t *testing.T) {
+	calls := int64(0)
+	task := func(cancelC <-chan struct{}) error {
+		n := atomic.AddInt64(&calls, 1)
+		if n < 3 {
+			return errors.New("not yet")
+		}
+		return nil
+	}
+
+	s := NewSupervisor(task, RestartOnError, ConstantBackoff(time.Millisecond))
+
+	require.NoError(t, s.Task()(make(chan struct{})))
+	require.Equal(t, int64(3), atomic.LoadInt64(&calls))
+}
+
+func TestSupervisor_RestartNeverReturnsImmediately(t *testing.T) {
+	calls := int64(0)
+	task := func(cancelC <-chan struct{}) error {
+		atomic.AddInt64(&calls, 1)
+		return errors.New("boom")
+	}
+
+	s := NewSupervisor(task, RestartNever, ConstantBackoff(time.Millisecond))
+
+	require.EqualError(t, s.Task()(make(chan struct{})), "boom")
+	require.Equal(t, int64(1), atomic.LoadInt64(&calls))
+}
+
+func TestSupervisor_GivesUpAfterMaxRestarts(t *testing.T) {
+	calls := int64(0)
+	task := func(cancelC <-chan struct{}) error {
+		atomic.AddInt64(&calls, 1)
+		return errors.New("boom")
+	}
+
+	s := NewSupervisor(task, RestartOnError, ConstantBackoff(time.Millisecond), WithMaxRestarts(2))
+
+	require.EqualError(t, s.Task()(make(chan struct{})), "boom")
+	require.Equal(t, int64(3), atomic.LoadInt64(&calls)) // initial attempt + 2 restarts
+}
+
+func TestSupervisor_HonorsCancelBetweenRestarts(t *testing.T) {
+	calls := int64(0)
+	task := func(cancelC <-chan struct{}) error {
+		atomic.AddInt64(&calls, 1)
+		return errors.New("boom")
+	}
+
+	s := NewSupervisor(task, RestartOnError, ConstantBackoff(time.Minute))
+
+	cancelC := make(chan struct{})
+	doneC := make(chan error, 1)
+	go func() {
+		doneC <- s.Task()(cancelC)
+	}()
+
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt64(&calls) == 1
+	}, time.Second, time.Millisecond*10)
+
+	close(cancelC)
+
+	select {
+	case err := <-doneC:
+		require.EqualError(t, err, "boom")
+	case <-time.After(time.Second * 5):
+		t.Fatal("supervised task did not observe cancellation while backing off")
+	}
+}
+
+func TestSupervisor_ObserverReceivesRestartEvents(t *testing.T) {
+	calls := int64(0)
+	task := func(cancelC <-chan struct{}) error {
+		n := atomic.AddInt64(&calls, 1)
+		if n < 3 {
+			return errors.New("not yet")
+		}
+		return nil
+	}
+
+	eventC := make(chan RestartEvent, 2)
+	s := NewSupervisor(task, RestartOnError, ConstantBackoff(time.Millisecond), WithObserver(eventC))
+
+	require.NoError(t, s.Task()(make(chan struct{})))
+
+	event1 := <-eventC
+	require.Equal(t, 1, event1.Attempt)
+	require.EqualError(t, event1.Err, "not yet")
+
+	event2 := <-eventC
+	require.Equal(t, 2, event2.Attempt)
+}
+
+func TestExponentialBackoff_CapsAndStaysNonNegative(t *testing.T) {
+	backoff := ExponentialBackoff(time.Millisecond, time.Millisecond*10)
+
+	for attempt := 1; attempt <= 10; attempt++ {
+		d := backoff(attempt)
+		require.GreaterOrEqual(t, d, time.Duration(0))
+		require.LessOrEqual(t, d, time.Millisecond*10)
+	}
+}
+
+func TestExponentialBackoff_ZeroBaseMeansNoDelay(t *testing.T) {
+	backoff := ExponentialBackoff(0, time.Second)
+
+	for attempt := 1; attempt <= 5; attempt++ {
+		require.Equal(t, time.Duration(0), backoff(attempt))
+	}
+}
@@ -14,7 +14,10 @@
 
 package go_tasks
 
-import "errors"
+import (
+	"context"
+	"errors"
+)
 
 // ErrTaskCancelled indicates a task was cancelled.
 //
@@ -44,3 +47,11 @@ var ErrTaskCancelled = errors.New("task cancelled")
 //  	}
 //   }
 type Task func(cancelC <-chan struct{}) error
+
+// TaskCtx represents an independent worker whose cancellation is expressed via a context.Context rather than a bare
+// channel.
+//
+// TaskCtx exists alongside Task so callers can propagate deadlines, cancellation causes, and request-scoped values
+// from a parent context.Context; ctx.Done() plays the same role Task's cancelC does. DynamicTaskGroup.GoCtx runs
+// TaskCtx tasks directly, and DynamicTaskGroup.Go adapts a plain Task into one.
+type TaskCtx func(ctx context.Context) error